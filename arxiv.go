@@ -36,6 +36,12 @@ type Paper struct {
 	Comment string
 	// Number of pages. 0 if not specified.
 	Pages uint
+
+	// Extensions holds values decoded by an ExtensionParser registered
+	// with Client.RegisterExtension, keyed first by namespace URI and
+	// then by local element name. It is nil unless at least one
+	// extension was registered and matched.
+	Extensions map[string]map[string][]any
 }
 
 // Method ID returns the arXiv ID of the paper.
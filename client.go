@@ -0,0 +1,149 @@
+package arxiv
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Type Client wraps an *http.Client with the rate limiting and retry
+// behavior required by the arXiv terms of use
+// (https://arxiv.org/help/api/tou): no more than one request every
+// three seconds, and backing off when arXiv asks us to slow down.
+//
+// The zero Client is not ready to use; construct one with NewClient.
+type Client struct {
+	// HTTPClient is used to make requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// MinInterval is the minimum time between the start of consecutive
+	// requests. Defaults to 3 seconds, per the arXiv terms of use.
+	MinInterval time.Duration
+
+	// MaxRetries is the number of times a request is retried after a
+	// 503 ("slow down") response before giving up. Defaults to 5.
+	MaxRetries int
+
+	// RetryBaseDelay is the base of the exponential backoff used when
+	// arXiv returns a 503 without a Retry-After header. Defaults to 1
+	// second.
+	RetryBaseDelay time.Duration
+
+	limiter    rateLimiter
+	extensions map[extensionKey]ExtensionParser
+}
+
+// DefaultClient is the Client used by the package-level Search function.
+var DefaultClient = NewClient()
+
+// Function NewClient returns a Client configured with the defaults
+// required by the arXiv terms of use.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient:     http.DefaultClient,
+		MinInterval:    3 * time.Second,
+		MaxRetries:     5,
+		RetryBaseDelay: 1 * time.Second,
+	}
+}
+
+// rateLimiter is a minimal token-bucket-of-one limiter: it only ever
+// allows a single request in flight every interval.
+type rateLimiter struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Method wait blocks until it is safe to issue another request, or
+// until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, interval time.Duration) error {
+	l.mu.Lock()
+	now := time.Now()
+	wait := l.last.Add(interval).Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	l.last = now.Add(wait)
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Method do issues req, retrying on 503 responses up to c.MaxRetries
+// times. It honors the Retry-After header when present and falls back
+// to exponential backoff with jitter otherwise.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	interval := c.MinInterval
+	if interval == 0 {
+		interval = 3 * time.Second
+	}
+	baseDelay := c.RetryBaseDelay
+	if baseDelay == 0 {
+		baseDelay = 1 * time.Second
+	}
+	maxRetries := c.MaxRetries
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		resp, err := httpClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusServiceUnavailable || attempt >= maxRetries {
+			return resp, nil
+		}
+
+		delay := retryDelay(resp.Header.Get("Retry-After"), baseDelay, attempt)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Function retryDelay determines how long to wait before retrying a
+// request that received a 503, preferring the server-supplied
+// Retry-After header over exponential backoff.
+func retryDelay(retryAfter string, baseDelay time.Duration, attempt int) time.Duration {
+	if retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(retryAfter); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+
+	delay := baseDelay << attempt
+	jitter := time.Duration(rand.Int63n(int64(baseDelay) + 1))
+	return delay + jitter
+}
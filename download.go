@@ -0,0 +1,184 @@
+package arxiv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Method DownloadPDF fetches the paper's PDF into w using DefaultClient.
+func (p *Paper) DownloadPDF(ctx context.Context, w io.Writer) (int64, error) {
+	return DefaultClient.downloadTo(ctx, pdfURL(p.ID()), w)
+}
+
+// Method DownloadSource fetches the paper's source (usually a gzipped
+// tarball or, for older single-file submissions, a bare TeX file) into
+// w using DefaultClient.
+func (p *Paper) DownloadSource(ctx context.Context, w io.Writer) (int64, error) {
+	return DefaultClient.downloadTo(ctx, sourceURL(p.ID()), w)
+}
+
+// Method DownloadPDF fetches the paper's PDF into w, going through c's
+// rate limiter and retry policy like a search would.
+//
+// If w implements io.Seeker, DownloadPDF resumes a partial download
+// from w's current offset using an HTTP Range request, falling back to
+// a full download from the start if the server doesn't honor it.
+func (c *Client) DownloadPDF(ctx context.Context, p *Paper, w io.Writer) (int64, error) {
+	return c.downloadTo(ctx, pdfURL(p.ID()), w)
+}
+
+// Method DownloadSource fetches the paper's source into w; see
+// DownloadPDF for resumption semantics.
+func (c *Client) DownloadSource(ctx context.Context, p *Paper, w io.Writer) (int64, error) {
+	return c.downloadTo(ctx, sourceURL(p.ID()), w)
+}
+
+func pdfURL(id string) string {
+	return "https://arxiv.org/pdf/" + strings.TrimPrefix(id, "arXiv:")
+}
+
+func sourceURL(id string) string {
+	return "https://arxiv.org/e-print/" + strings.TrimPrefix(id, "arXiv:")
+}
+
+func (c *Client) downloadTo(ctx context.Context, downloadURL string, w io.Writer) (int64, error) {
+	var offset int64
+	if seeker, ok := w.(io.Seeker); ok {
+		var err error
+		offset, err = seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, fmt.Errorf("failed to determine resume offset: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build download request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Server ignored the Range request; if we asked to resume, we
+		// need to start the file over. If w also has a Truncate method,
+		// the partial content already there is discarded so stale
+		// trailing bytes beyond the fresh body's length don't survive;
+		// otherwise w is only rewound.
+		if offset > 0 {
+			seeker := w.(io.Seeker)
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return 0, fmt.Errorf("failed to restart download: %w", err)
+			}
+			if truncater, ok := w.(interface{ Truncate(int64) error }); ok {
+				if err := truncater.Truncate(0); err != nil {
+					return 0, fmt.Errorf("failed to restart download: %w", err)
+				}
+			}
+		}
+	case http.StatusPartialContent:
+		// resuming as requested
+	default:
+		return 0, fmt.Errorf("failed to download: unexpected status %s", resp.Status)
+	}
+
+	n, err := io.Copy(newCtxWriter(ctx, w), resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download: %w", err)
+	}
+	return n, nil
+}
+
+// ctxWriter wraps an io.Writer so that writes fail with ctx.Err() once
+// ctx is done, letting a cancelled context interrupt an in-progress
+// download.
+type ctxWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func newCtxWriter(ctx context.Context, w io.Writer) io.Writer {
+	return &ctxWriter{ctx: ctx, w: w}
+}
+
+func (c *ctxWriter) Write(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.w.Write(p)
+}
+
+var nonWordRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Method SaveTo downloads the paper's PDF and a JSON sidecar with its
+// metadata into dir, using the crane filename convention
+// "<first-author-lastname>_<year>_<short-title>.pdf".
+func (p *Paper) SaveTo(ctx context.Context, dir string) error {
+	base := filepath.Join(dir, saveName(p))
+
+	pdfPath := base + ".pdf"
+	f, err := os.Create(pdfPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", pdfPath, err)
+	}
+	_, err = p.DownloadPDF(ctx, f)
+	closeErr := f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to save PDF: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to save PDF: %w", closeErr)
+	}
+
+	metaPath := base + ".json"
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := os.WriteFile(metaPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	return nil
+}
+
+func saveName(p *Paper) string {
+	lastName := "unknown"
+	if len(p.Authors) > 0 {
+		fields := strings.Fields(p.Authors[0].Name)
+		if len(fields) > 0 {
+			lastName = fields[len(fields)-1]
+		}
+	}
+
+	year := "0000"
+	if !p.Published.IsZero() {
+		year = strconv.Itoa(p.Published.Year())
+	}
+
+	title := nonWordRe.ReplaceAllString(strings.ToLower(p.Title), "-")
+	title = strings.Trim(title, "-")
+	if len(title) > 40 {
+		title = strings.Trim(title[:40], "-")
+	}
+	if title == "" {
+		title = "untitled"
+	}
+
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(lastName), year, title)
+}
@@ -0,0 +1,74 @@
+package arxiv
+
+import "encoding/xml"
+
+// Type ExtensionParser decodes a single XML element that the core
+// Paper fields don't capture, such as a Dublin Core or Prism element,
+// or an arXiv-schema addition the package doesn't know about yet.
+//
+// Parse is called with d positioned just after start; it must consume
+// exactly start's element, including its matching EndElement, the same
+// way parsePaper and parseAuthor do.
+type ExtensionParser interface {
+	Parse(d *xml.Decoder, start xml.StartElement) (any, error)
+}
+
+// Type extensionKey identifies the namespace and local name an
+// ExtensionParser was registered for.
+type extensionKey struct {
+	namespaceURI string
+	localName    string
+}
+
+// Method RegisterExtension registers parser to handle elements named
+// localName in namespaceURI, both within an entry's <atom:entry> (the
+// decoded value is appended to the resulting Paper's Extensions) and,
+// when the same element appears at the feed root, aggregated on the
+// returned SearchResult.Extensions.
+func (c *Client) RegisterExtension(namespaceURI, localName string, parser ExtensionParser) {
+	if c.extensions == nil {
+		c.extensions = make(map[extensionKey]ExtensionParser)
+	}
+	c.extensions[extensionKey{namespaceURI, localName}] = parser
+}
+
+func (c *Client) extensionParser(namespaceURI, localName string) ExtensionParser {
+	if c.extensions == nil {
+		return nil
+	}
+	return c.extensions[extensionKey{namespaceURI, localName}]
+}
+
+// Function addExtension records value under Extensions, initializing
+// the nested maps as needed.
+func addExtension(extensions *map[string]map[string][]any, namespaceURI, localName string, value any) {
+	if *extensions == nil {
+		*extensions = make(map[string]map[string][]any)
+	}
+	if (*extensions)[namespaceURI] == nil {
+		(*extensions)[namespaceURI] = make(map[string][]any)
+	}
+	(*extensions)[namespaceURI][localName] = append((*extensions)[namespaceURI][localName], value)
+}
+
+// Type SearchResult is the full response to a search, including the
+// OpenSearch pagination metadata arXiv reports on the feed root, so
+// that callers can tell how many results exist in total instead of
+// guessing from how many came back.
+type SearchResult struct {
+	Papers []Paper
+
+	// TotalResults is the total number of papers matching the query,
+	// which may be far larger than len(Papers).
+	TotalResults int
+	// StartIndex is the index of the first paper in Papers, matching
+	// the Query's Start.
+	StartIndex int
+	// ItemsPerPage is the number of results arXiv returned per page,
+	// matching the Query's Max.
+	ItemsPerPage int
+
+	// Extensions holds values decoded by an ExtensionParser registered
+	// for elements found on the feed root rather than inside an entry.
+	Extensions map[string]map[string][]any
+}
@@ -0,0 +1,244 @@
+package arxiv
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BM25 parameters used by MemoryIndexer, per Robertson & Zaragoza's
+// recommended defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var tokenRe = regexp.MustCompile(`[\pL\pN]+`)
+
+// tokenize lowercases s and splits it on runs of non-letter,
+// non-number characters.
+func tokenize(s string) []string {
+	return tokenRe.FindAllString(strings.ToLower(s), -1)
+}
+
+// fieldPrefixes maps the query-language field prefixes to the document
+// field they search, mirroring the search_query field prefixes arXiv
+// itself uses (https://arxiv.org/help/api/user-manual#query_details).
+var fieldPrefixes = map[string]string{
+	"ti":  "title",
+	"abs": "summary",
+	"au":  "authors",
+	"cat": "categories",
+	"jr":  "journal",
+	"all": "all",
+}
+
+// Type memDoc is the indexed form of a Paper: its original value plus
+// a tokenized copy of each searchable field.
+type memDoc struct {
+	paper  Paper
+	fields map[string][]string
+	length int
+}
+
+// Type MemoryIndexer is the default Indexer: an in-memory inverted
+// index over a Paper's title, summary, authors, categories and
+// journal, ranked with BM25 (k1=1.2, b=0.75).
+type MemoryIndexer struct {
+	mu   sync.RWMutex
+	docs map[string]*memDoc
+
+	// postings[field][token] is the set of document IDs whose field
+	// contains token.
+	postings map[string]map[string]map[string]bool
+
+	totalLength int
+}
+
+// Function NewMemoryIndexer returns an empty MemoryIndexer.
+func NewMemoryIndexer() *MemoryIndexer {
+	return &MemoryIndexer{
+		docs:     make(map[string]*memDoc),
+		postings: make(map[string]map[string]map[string]bool),
+	}
+}
+
+func fieldsOf(p Paper) map[string][]string {
+	authors := make([]string, len(p.Authors))
+	for i, a := range p.Authors {
+		authors[i] = a.Name
+	}
+
+	fields := map[string][]string{
+		"title":      tokenize(p.Title),
+		"summary":    tokenize(p.Summary),
+		"authors":    tokenize(strings.Join(authors, " ")),
+		"categories": tokenize(strings.Join(p.Categories, " ")),
+		"journal":    tokenize(p.Journal),
+	}
+
+	all := make([]string, 0)
+	for _, field := range []string{"title", "summary", "authors", "categories", "journal"} {
+		all = append(all, fields[field]...)
+	}
+	fields["all"] = all
+
+	return fields
+}
+
+// Method Add indexes p, replacing any existing document with the same
+// Paper.ID().
+func (idx *MemoryIndexer) Add(p Paper) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	id := p.ID()
+	if old, ok := idx.docs[id]; ok {
+		idx.removeLocked(id, old)
+	}
+
+	fields := fieldsOf(p)
+	doc := &memDoc{paper: p, fields: fields, length: len(fields["all"])}
+	idx.docs[id] = doc
+	idx.totalLength += doc.length
+
+	for field, tokens := range fields {
+		if idx.postings[field] == nil {
+			idx.postings[field] = make(map[string]map[string]bool)
+		}
+		for _, token := range tokens {
+			if idx.postings[field][token] == nil {
+				idx.postings[field][token] = make(map[string]bool)
+			}
+			idx.postings[field][token][id] = true
+		}
+	}
+
+	return nil
+}
+
+// Method Delete removes the document with the given arXiv ID, if
+// present.
+func (idx *MemoryIndexer) Delete(id string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	doc, ok := idx.docs[id]
+	if !ok {
+		return nil
+	}
+	idx.removeLocked(id, doc)
+	return nil
+}
+
+// removeLocked removes doc from the index. Callers must hold idx.mu.
+func (idx *MemoryIndexer) removeLocked(id string, doc *memDoc) {
+	for field, tokens := range doc.fields {
+		for _, token := range tokens {
+			delete(idx.postings[field][token], id)
+		}
+	}
+	idx.totalLength -= doc.length
+	delete(idx.docs, id)
+}
+
+// Method Search parses query into field-qualified and free-text terms
+// (e.g. "au:hinton cat:cs.LG deep learning") and ranks matching
+// documents by BM25 score, highest first.
+func (idx *MemoryIndexer) Search(query string, limit int) ([]Paper, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	terms := parseIndexQuery(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	avgLength := 1.0
+	if len(idx.docs) > 0 {
+		avgLength = float64(idx.totalLength) / float64(len(idx.docs))
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		postings := idx.postings[term.field][term.token]
+		docFreq := len(postings)
+		if docFreq == 0 {
+			continue
+		}
+		idf := bm25IDF(len(idx.docs), docFreq)
+
+		for id := range postings {
+			doc := idx.docs[id]
+			freq := termFreq(doc.fields[term.field], term.token)
+			norm := 1 - bm25B + bm25B*float64(doc.length)/avgLength
+			scores[id] += idf * (freq * (bm25K1 + 1)) / (freq + bm25K1*norm)
+		}
+	}
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	out := make([]Paper, len(ids))
+	for i, id := range ids {
+		out[i] = idx.docs[id].paper
+	}
+	return out, nil
+}
+
+func bm25IDF(docCount, docFreq int) float64 {
+	return math.Log((float64(docCount)-float64(docFreq)+0.5)/(float64(docFreq)+0.5) + 1)
+}
+
+func termFreq(tokens []string, token string) float64 {
+	n := 0
+	for _, t := range tokens {
+		if t == token {
+			n++
+		}
+	}
+	return float64(n)
+}
+
+// Type indexTerm is a single parsed query term: a token restricted to
+// field (or "all" for unqualified terms).
+type indexTerm struct {
+	field string
+	token string
+}
+
+// parseIndexQuery splits a query string into indexTerms, recognizing
+// the field prefixes in fieldPrefixes (e.g. "au:hinton") and falling
+// back to the "all" field for anything else.
+func parseIndexQuery(query string) []indexTerm {
+	var terms []indexTerm
+	for _, word := range strings.Fields(query) {
+		field := "all"
+		term := word
+		if i := strings.Index(word, ":"); i > 0 {
+			if f, ok := fieldPrefixes[word[:i]]; ok {
+				field = f
+				term = word[i+1:]
+			}
+		}
+
+		for _, token := range tokenize(term) {
+			terms = append(terms, indexTerm{field: field, token: token})
+		}
+	}
+	return terms
+}
@@ -0,0 +1,86 @@
+package arxiv
+
+import "context"
+
+// Type Indexer stores Papers and answers full-text search queries over
+// them. Library's zero value uses NewMemoryIndexer; callers can supply
+// their own Indexer to back a Library with a different storage engine.
+type Indexer interface {
+	Add(Paper) error
+	Search(query string, limit int) ([]Paper, error)
+	Delete(id string) error
+}
+
+// Type Library is a local collection of Papers backed by an Indexer,
+// letting callers build offline search over an arXiv subset without
+// standing up a separate search engine.
+type Library struct {
+	// Client is used by Ingest to run queries. Defaults to DefaultClient.
+	Client *Client
+
+	indexer Indexer
+}
+
+// Function NewLibrary returns a Library backed by indexer. If indexer
+// is nil, a NewMemoryIndexer is used. If client is nil, DefaultClient
+// is used.
+func NewLibrary(client *Client, indexer Indexer) *Library {
+	if client == nil {
+		client = DefaultClient
+	}
+	if indexer == nil {
+		indexer = NewMemoryIndexer()
+	}
+	return &Library{Client: client, indexer: indexer}
+}
+
+// Method Add indexes paper, replacing any existing entry with the same
+// Paper.ID().
+func (l *Library) Add(paper Paper) error {
+	return l.idx().Add(paper)
+}
+
+// Method Search runs a full-text query against the library. query may
+// include field-qualified terms such as "au:hinton" or "cat:cs.LG"
+// alongside free text, e.g. "au:hinton cat:cs.LG deep learning".
+func (l *Library) Search(query string, limit int) ([]Paper, error) {
+	return l.idx().Search(query, limit)
+}
+
+// Method Delete removes the paper with the given arXiv ID from the
+// library, if present.
+func (l *Library) Delete(id string) error {
+	return l.idx().Delete(id)
+}
+
+// Method Ingest runs q via l.Client and adds every result to the
+// library. It is safe to call repeatedly: papers are deduplicated by
+// Paper.ID(), so re-running the same (or an overlapping) query only
+// refreshes existing entries instead of duplicating them.
+func (l *Library) Ingest(ctx context.Context, q *Query) error {
+	client := l.Client
+	if client == nil {
+		client = DefaultClient
+	}
+
+	result, err := client.SearchContext(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	for _, paper := range result.Papers {
+		if err := l.idx().Add(paper); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// idx returns l's Indexer, lazily initializing it to a NewMemoryIndexer
+// so that the zero Library is ready to use.
+func (l *Library) idx() Indexer {
+	if l.indexer == nil {
+		l.indexer = NewMemoryIndexer()
+	}
+	return l.indexer
+}
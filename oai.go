@@ -0,0 +1,603 @@
+package arxiv
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// oaiEndpoint is arXiv's OAI-PMH endpoint. Unlike the search API it has
+// no 30,000-result cap and supports incremental harvesting via
+// resumptionToken.
+//
+// For more information, see https://arxiv.org/help/oa.
+const oaiEndpoint = "http://export.arxiv.org/oai2"
+
+// Type OAIParams selects the records an OAIHarvester lists.
+type OAIParams struct {
+	// MetadataPrefix selects the metadata schema: "arXiv" for the
+	// standard fields, or "arXivRaw" for the richer schema including
+	// version history, license and report numbers. Defaults to
+	// "arXiv".
+	MetadataPrefix string
+
+	// Set restricts harvesting to a category set, e.g. "cs" or
+	// "physics:hep-th". Optional.
+	Set string
+
+	// From and Until bound the selection by the date records were
+	// last changed. Either may be zero to leave that bound open.
+	From, Until time.Time
+}
+
+// Type PaperVersion describes one revision of a paper, as reported by
+// the arXivRaw metadata schema.
+type PaperVersion struct {
+	Version string
+	Created time.Time
+	// Size is the submission size as reported by arXiv, e.g. "1234kb".
+	Size string
+}
+
+// Type PaperRaw extends Paper with the fields only available through
+// OAIHarvester's arXivRaw metadata prefix: license, report number and
+// full version history.
+type PaperRaw struct {
+	Paper
+
+	License      string
+	ReportNumber string
+	Versions     []PaperVersion
+}
+
+// Type OAIRecord is one record yielded by OAIHarvester.ListRecords.
+type OAIRecord struct {
+	Paper PaperRaw
+
+	// Deleted is true if arXiv reports this record as withdrawn from
+	// the set/prefix harvested; Paper only contains the ID in that case.
+	Deleted bool
+}
+
+// Type OAIHarvester harvests paper metadata in bulk from arXiv's
+// OAI-PMH interface, following resumptionToken pages transparently.
+// It is the right tool for mirrors and nightly deltas; for one-off
+// queries, use Search instead.
+type OAIHarvester struct {
+	// Client is used to execute requests, so harvesting shares the
+	// same rate limit and retry policy as Search. Defaults to
+	// DefaultClient.
+	Client *Client
+
+	// BaseURL is the OAI-PMH endpoint. Defaults to oaiEndpoint.
+	BaseURL string
+}
+
+// Function NewOAIHarvester returns an OAIHarvester using client. If
+// client is nil, DefaultClient is used.
+func NewOAIHarvester(client *Client) *OAIHarvester {
+	if client == nil {
+		client = DefaultClient
+	}
+	return &OAIHarvester{Client: client, BaseURL: oaiEndpoint}
+}
+
+// Method ListRecords harvests every record matching params, following
+// resumptionToken pages until exhausted. Records are delivered on the
+// returned channel as they're parsed; the channel is closed when
+// harvesting finishes, ctx is done, or an error occurs (delivered as
+// the last, non-zero-Err OAIResult).
+func (h *OAIHarvester) ListRecords(ctx context.Context, params OAIParams) <-chan OAIResult {
+	out := make(chan OAIResult)
+	go func() {
+		defer close(out)
+
+		prefix := params.MetadataPrefix
+		if prefix == "" {
+			prefix = "arXiv"
+		}
+
+		values := url.Values{}
+		values.Set("verb", "ListRecords")
+		values.Set("metadataPrefix", prefix)
+		if params.Set != "" {
+			values.Set("set", params.Set)
+		}
+		if !params.From.IsZero() {
+			values.Set("from", params.From.Format("2006-01-02"))
+		}
+		if !params.Until.IsZero() {
+			values.Set("until", params.Until.Format("2006-01-02"))
+		}
+
+		baseURL := h.BaseURL
+		if baseURL == "" {
+			baseURL = oaiEndpoint
+		}
+		client := h.Client
+		if client == nil {
+			client = DefaultClient
+		}
+
+		for {
+			req, err := http.NewRequest(http.MethodGet, baseURL+"?"+values.Encode(), nil)
+			if err != nil {
+				out <- OAIResult{Err: fmt.Errorf("failed to build OAI-PMH request: %w", err)}
+				return
+			}
+
+			resp, err := client.do(ctx, req)
+			if err != nil {
+				out <- OAIResult{Err: fmt.Errorf("failed to execute OAI-PMH request: %w", err)}
+				return
+			}
+
+			token, err := deliverOAIPage(ctx, resp.Body, prefix, out)
+			resp.Body.Close()
+			if err != nil {
+				out <- OAIResult{Err: err}
+				return
+			}
+			if token == "" {
+				return
+			}
+
+			values = url.Values{}
+			values.Set("verb", "ListRecords")
+			values.Set("resumptionToken", token)
+		}
+	}()
+	return out
+}
+
+// Type OAIResult is a value delivered on the channel returned by
+// OAIHarvester.ListRecords: either a Record, or a terminal Err.
+type OAIResult struct {
+	Record OAIRecord
+	Err    error
+}
+
+// deliverOAIPage parses a single ListRecords response body, sending
+// each record on out, and returns the resumptionToken for the next
+// page (empty if there isn't one).
+func deliverOAIPage(ctx context.Context, body io.Reader, prefix string, out chan<- OAIResult) (string, error) {
+	d := xml.NewDecoder(newCtxReader(ctx, body))
+
+	var token string
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		start, ok := tk.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "error":
+			var msg string
+			if err := getInnerValue(d, &msg); err != nil {
+				return "", err
+			}
+			return "", fmt.Errorf("OAI-PMH error: %s", msg)
+
+		case "record":
+			record, err := parseOAIRecord(d, prefix)
+			if err != nil {
+				return "", err
+			}
+			select {
+			case out <- OAIResult{Record: record}:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+
+		case "resumptionToken":
+			if err := getInnerValue(d, &token); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return token, nil
+}
+
+// parseOAIRecord parses a single <record> element, starting just after
+// its StartElement has been consumed.
+func parseOAIRecord(d *xml.Decoder, prefix string) (OAIRecord, error) {
+	var record OAIRecord
+
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return record, nil
+		}
+		if err != nil {
+			return record, fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "record" {
+				return record, nil
+			}
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "header":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "status" && attr.Value == "deleted" {
+						record.Deleted = true
+					}
+				}
+			case "identifier":
+				var id string
+				if err := getInnerValue(d, &id); err != nil {
+					return record, err
+				}
+				record.Paper.URL = oaiIdentifierToURL(id)
+			case "metadata":
+				if record.Deleted {
+					continue
+				}
+				if err := parseOAIMetadata(d, prefix, &record.Paper); err != nil {
+					return record, err
+				}
+			}
+		}
+	}
+}
+
+// oaiIdentifierToURL turns an OAI identifier like
+// "oai:arXiv.org:2301.12345" into the same /abs/ URL Paper.ID expects.
+func oaiIdentifierToURL(id string) string {
+	i := strings.LastIndex(id, ":")
+	if i < 0 {
+		return ""
+	}
+	return "https://arxiv.org/abs/" + id[i+1:]
+}
+
+// parseOAIMetadata parses the <arXiv> or <arXivRaw> element nested
+// inside <metadata>.
+func parseOAIMetadata(d *xml.Decoder, prefix string, paper *PaperRaw) error {
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "metadata" {
+				return nil
+			}
+		case xml.StartElement:
+			if strings.EqualFold(t.Name.Local, prefix) {
+				return parseOAIPaper(d, prefix, paper)
+			}
+		}
+	}
+}
+
+func parseOAIPaper(d *xml.Decoder, prefix string, paper *PaperRaw) error {
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if strings.EqualFold(t.Name.Local, "arXiv") || strings.EqualFold(t.Name.Local, "arXivRaw") {
+				return nil
+			}
+		case xml.StartElement:
+			var str string
+			switch t.Name.Local {
+			case "id":
+				if err := getInnerValue(d, &str); err != nil {
+					return err
+				}
+				paper.URL = "https://arxiv.org/abs/" + str
+			case "title":
+				if err := getInnerValue(d, &paper.Title); err != nil {
+					return err
+				}
+				paper.Title = string(spaceRe.ReplaceAll([]byte(paper.Title), []byte(" ")))
+				paper.Title = strings.Trim(paper.Title, " \t\n")
+			case "abstract":
+				if err := getInnerValue(d, &paper.Summary); err != nil {
+					return err
+				}
+				paper.Summary = string(spaceRe.ReplaceAll([]byte(paper.Summary), []byte(" ")))
+				paper.Summary = strings.Trim(paper.Summary, " \t\n")
+			case "authors":
+				// The arXiv prefix nests structured <author><keyname/>
+				// <forenames/></author> elements; only arXivRaw gives a
+				// flat "Last, First and Last2, First2" string.
+				if strings.EqualFold(prefix, "arXivRaw") {
+					if err := getInnerValue(d, &str); err != nil {
+						return err
+					}
+					paper.Authors = parseOAIAuthors(str)
+				} else {
+					authors, err := parseOAIStructuredAuthors(d)
+					if err != nil {
+						return err
+					}
+					paper.Authors = authors
+				}
+			case "categories":
+				if err := getInnerValue(d, &str); err != nil {
+					return err
+				}
+				paper.Categories = strings.Fields(str)
+			case "comments":
+				if err := getInnerValue(d, &paper.Comment); err != nil {
+					return err
+				}
+			case "journal-ref":
+				if err := getInnerValue(d, &paper.Journal); err != nil {
+					return err
+				}
+			case "doi":
+				if err := getInnerValue(d, &paper.DOI); err != nil {
+					return err
+				}
+			case "license":
+				if err := getInnerValue(d, &paper.License); err != nil {
+					return err
+				}
+			case "report-no":
+				if err := getInnerValue(d, &paper.ReportNumber); err != nil {
+					return err
+				}
+			case "created":
+				if err := getInnerValue(d, &str); err != nil {
+					return err
+				}
+				if created, err := time.Parse("2006-01-02", str); err == nil {
+					paper.Published = created
+				}
+			case "updated":
+				if err := getInnerValue(d, &str); err != nil {
+					return err
+				}
+				if updated, err := time.Parse("2006-01-02", str); err == nil {
+					paper.Updated = updated
+				}
+			case "version":
+				// arXivRaw emits <version version="v1">...</version> as
+				// a direct child, with no <versions> wrapper.
+				version := PaperVersion{}
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "version" {
+						version.Version = attr.Value
+					}
+				}
+				if err := parseOAIVersion(d, &version); err != nil {
+					return err
+				}
+				paper.Versions = append(paper.Versions, version)
+			case "versions":
+				if err := parseOAIVersions(d, paper); err != nil {
+					return err
+				}
+			default:
+				if err := skipOAIElement(d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// parseOAIAuthors parses the arXivRaw "Last, First and Last2, First2
+// and ..." author list format into Authors, reordering each name to
+// the "First Last" form Paper.Authors otherwise uses.
+func parseOAIAuthors(str string) []Author {
+	var authors []Author
+	for _, part := range strings.Split(str, " and ") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if last, first, ok := strings.Cut(part, ","); ok {
+			part = strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+		}
+		authors = append(authors, Author{Name: part})
+	}
+	return authors
+}
+
+// parseOAIStructuredAuthors parses the arXiv-prefix <authors> element,
+// whose children are structured <author><keyname/><forenames/></author>
+// elements rather than a flat string.
+func parseOAIStructuredAuthors(d *xml.Decoder) ([]Author, error) {
+	var authors []Author
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return authors, nil
+		}
+		if err != nil {
+			return authors, fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "authors" {
+				return authors, nil
+			}
+		case xml.StartElement:
+			if t.Name.Local != "author" {
+				if err := skipOAIElement(d); err != nil {
+					return authors, err
+				}
+				continue
+			}
+
+			author, err := parseOAIStructuredAuthor(d)
+			if err != nil {
+				return authors, err
+			}
+			authors = append(authors, author)
+		}
+	}
+}
+
+// parseOAIStructuredAuthor parses a single <author> element of the
+// arXiv-prefix <authors> list into the "First Last" form Paper.Authors
+// otherwise uses.
+func parseOAIStructuredAuthor(d *xml.Decoder) (Author, error) {
+	var author Author
+	var keyname, forenames, suffix string
+
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return author, fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "author" {
+				author.Name = strings.TrimSpace(strings.TrimSpace(forenames+" "+keyname) + " " + suffix)
+				return author, nil
+			}
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "keyname":
+				err = getInnerValue(d, &keyname)
+			case "forenames":
+				err = getInnerValue(d, &forenames)
+			case "suffix":
+				err = getInnerValue(d, &suffix)
+			case "affiliation":
+				err = getInnerValue(d, &author.Affiliation)
+			default:
+				err = skipOAIElement(d)
+			}
+			if err != nil {
+				return author, err
+			}
+		}
+	}
+
+	author.Name = strings.TrimSpace(strings.TrimSpace(forenames+" "+keyname) + " " + suffix)
+	return author, nil
+}
+
+func parseOAIVersions(d *xml.Decoder, paper *PaperRaw) error {
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "versions" {
+				return nil
+			}
+		case xml.StartElement:
+			if t.Name.Local != "version" {
+				if err := skipOAIElement(d); err != nil {
+					return err
+				}
+				continue
+			}
+
+			version := PaperVersion{}
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "version" {
+					version.Version = attr.Value
+				}
+			}
+			if err := parseOAIVersion(d, &version); err != nil {
+				return err
+			}
+			paper.Versions = append(paper.Versions, version)
+		}
+	}
+}
+
+func parseOAIVersion(d *xml.Decoder, version *PaperVersion) error {
+	for {
+		tk, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+
+		switch t := tk.(type) {
+		case xml.EndElement:
+			if t.Name.Local == "version" {
+				return nil
+			}
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "date":
+				var str string
+				if err := getInnerValue(d, &str); err != nil {
+					return err
+				}
+				if created, err := time.Parse(time.RFC1123, str); err == nil {
+					version.Created = created
+				}
+			case "size":
+				if err := getInnerValue(d, &version.Size); err != nil {
+					return err
+				}
+			default:
+				if err := skipOAIElement(d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// skipOAIElement discards everything up to and including the matching
+// EndElement for the StartElement just consumed, for schema elements
+// this package doesn't model.
+func skipOAIElement(d *xml.Decoder) error {
+	depth := 1
+	for depth > 0 {
+		tk, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse OAI-PMH response: %w", err)
+		}
+		switch tk.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package arxiv
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -13,8 +14,11 @@ import (
 )
 
 const (
-	spaceAtom  = "http://www.w3.org/2005/Atom"
-	spaceArXiv = "http://arxiv.org/schemas/atom"
+	spaceAtom       = "http://www.w3.org/2005/Atom"
+	spaceArXiv      = "http://arxiv.org/schemas/atom"
+	spaceOpenSearch = "http://a9.com/-/spec/opensearch/1.0/"
+
+	searchEndpoint = "http://export.arxiv.org/api/query"
 )
 
 // Type SearchError represents an error resulting from a malformed request.
@@ -24,7 +28,29 @@ func (e SearchError) Error() string {
 	return string(e)
 }
 
+// Function Search executes q against the arXiv API using DefaultClient.
+//
+// Please note that you are not allowed to make more than 1 request every 3
+// seconds. For more information see the terms of use:
+// https://arxiv.org/help/api/tou.
 func Search(q *Query) ([]Paper, error) {
+	return DefaultClient.Search(q)
+}
+
+// Method Search executes q against the arXiv API, blocking as long as
+// necessary to respect c's rate limit and retry policy.
+func (c *Client) Search(q *Query) ([]Paper, error) {
+	result, err := c.SearchContext(context.Background(), q)
+	if err != nil {
+		return nil, err
+	}
+	return result.Papers, nil
+}
+
+// Method SearchContext executes q against the arXiv API. It respects
+// ctx both while waiting on the rate limiter and while decoding the
+// response, so a cancelled ctx stops an in-progress search promptly.
+func (c *Client) SearchContext(ctx context.Context, q *Query) (*SearchResult, error) {
 	values := url.Values{}
 	if q.Query != "" {
 		values.Set("search_query", q.Query)
@@ -41,15 +67,19 @@ func Search(q *Query) ([]Paper, error) {
 	values.Set("start", fmt.Sprint(q.Start))
 	values.Set("max_results", fmt.Sprint(q.Max))
 
-	//	log.Println("http://export.arxiv.org/api/query?" + values.Encode())
+	req, err := http.NewRequest(http.MethodGet, searchEndpoint+"?"+values.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search request: %w", err)
+	}
 
-	resp, err := http.Get("http://export.arxiv.org/api/query?" + values.Encode())
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute search: %w", err)
 	}
+	defer resp.Body.Close()
 
-	d := xml.NewDecoder(resp.Body)
-	out := make([]Paper, 0)
+	d := xml.NewDecoder(newCtxReader(ctx, resp.Body))
+	result := &SearchResult{Papers: make([]Paper, 0)}
 	for {
 		token, err := d.Token()
 		if err == io.EOF {
@@ -64,10 +94,10 @@ func Search(q *Query) ([]Paper, error) {
 			continue
 		}
 
-		if elem.Name.Local == "entry" && elem.Name.Space == spaceAtom {
-			out = append(out, Paper{})
-			paper := &out[len(out)-1]
-			err := parsePaper(d, paper)
+		if elem.Name.Space == spaceAtom && elem.Name.Local == "entry" {
+			result.Papers = append(result.Papers, Paper{})
+			paper := &result.Papers[len(result.Papers)-1]
+			err := parsePaper(d, paper, c)
 			if err != nil {
 				return nil, err
 			}
@@ -75,10 +105,55 @@ func Search(q *Query) ([]Paper, error) {
 			if strings.EqualFold(paper.Title, "error") {
 				return nil, SearchError(paper.Summary)
 			}
+			continue
+		}
+
+		if elem.Name.Space == spaceOpenSearch {
+			var str string
+			if err := getInnerValue(d, &str); err != nil {
+				return nil, err
+			}
+			n, _ := strconv.Atoi(str)
+			switch elem.Name.Local {
+			case "totalResults":
+				result.TotalResults = n
+			case "startIndex":
+				result.StartIndex = n
+			case "itemsPerPage":
+				result.ItemsPerPage = n
+			}
+			continue
+		}
+
+		if parser := c.extensionParser(elem.Name.Space, elem.Name.Local); parser != nil {
+			value, err := parser.Parse(d, elem)
+			if err != nil {
+				return nil, err
+			}
+			addExtension(&result.Extensions, elem.Name.Space, elem.Name.Local, value)
 		}
 	}
 
-	return out, nil
+	return result, nil
+}
+
+// ctxReader wraps an io.Reader so that reads fail with ctx.Err() once
+// ctx is done, letting a cancelled context interrupt an in-progress
+// xml.Decoder.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func newCtxReader(ctx context.Context, r io.Reader) io.Reader {
+	return &ctxReader{ctx: ctx, r: r}
+}
+
+func (c *ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
 }
 
 func getInnerValue(d *xml.Decoder, ret *string) error {
@@ -147,7 +222,7 @@ func parseAuthor(d *xml.Decoder, author *Author) error {
 
 var spaceRe = regexp.MustCompile("[\\s\n]+")
 
-func parsePaper(d *xml.Decoder, paper *Paper) error {
+func parsePaper(d *xml.Decoder, paper *Paper, c *Client) error {
 	for {
 		token, err := d.Token()
 		if err == io.EOF {
@@ -235,6 +310,10 @@ func parsePaper(d *xml.Decoder, paper *Paper) error {
 				if _, ok := end.(xml.EndElement); !ok {
 					return fmt.Errorf("failed to parse search results: expected EndElement")
 				}
+			default:
+				if err := parseExtension(d, elem, paper, c); err != nil {
+					return err
+				}
 			}
 		case spaceArXiv:
 			switch elem.Name.Local {
@@ -266,8 +345,34 @@ func parsePaper(d *xml.Decoder, paper *Paper) error {
 						}
 					}
 				}
+			default:
+				if err := parseExtension(d, elem, paper, c); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := parseExtension(d, elem, paper, c); err != nil {
+				return err
 			}
 		}
 	}
+}
+
+// parseExtension looks up an ExtensionParser for elem and, if one is
+// registered, uses it to decode elem into paper.Extensions. If none is
+// registered, elem is left for the caller's token loop to skip over,
+// matching the pre-extension behavior of silently discarding unknown
+// elements.
+func parseExtension(d *xml.Decoder, elem xml.StartElement, paper *Paper, c *Client) error {
+	parser := c.extensionParser(elem.Name.Space, elem.Name.Local)
+	if parser == nil {
+		return nil
+	}
+
+	value, err := parser.Parse(d, elem)
+	if err != nil {
+		return err
+	}
+	addExtension(&paper.Extensions, elem.Name.Space, elem.Name.Local, value)
 	return nil
 }
@@ -0,0 +1,230 @@
+package arxiv
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Type State is the persisted state of a Subscription: the cursor
+// needed to ask only for papers published after the last Poll.
+type State struct {
+	// LastPublished is the Published timestamp of the most recent
+	// paper seen so far.
+	LastPublished time.Time
+
+	// SeenIDs holds the arXiv IDs of every paper with Published equal
+	// to LastPublished, so that papers sharing that exact timestamp
+	// (or re-appearing after a withdrawal) aren't re-delivered.
+	SeenIDs []string
+}
+
+// Type StateStore persists and restores Subscription state between
+// runs.
+type StateStore interface {
+	Load() (State, error)
+	Save(State) error
+}
+
+// Type MemoryStore is a StateStore that keeps state in memory. It is
+// useful for tests or for subscriptions that only need to dedup within
+// a single process lifetime.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+func (s *MemoryStore) Load() (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *MemoryStore) Save(state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+// Type JSONFileStore is a StateStore that persists state as JSON in a
+// file on disk, so a Subscription can resume across process restarts.
+type JSONFileStore struct {
+	// Path is the file state is read from and written to. It need not
+	// exist yet; Load returns a zero State in that case.
+	Path string
+}
+
+func (s *JSONFileStore) Load() (State, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+func (s *JSONFileStore) Save(state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, data, 0644)
+}
+
+// Type Subscription polls a Query on an interval and delivers only the
+// papers that weren't seen on a previous Poll, using a StateStore to
+// remember the high-water mark across runs.
+//
+// For example, to follow new cs.LG papers and save progress to disk:
+//
+//	sub := arxiv.NewSubscription(arxiv.DefaultClient, &arxiv.Query{Query: "cat:cs.LG", Max: 50}, &arxiv.JSONFileStore{Path: "cs.LG.json"})
+//	sub.Interval = 10 * time.Minute
+//	for paper := range sub.Start(ctx) {
+//		notify(paper)
+//	}
+type Subscription struct {
+	// Client is used to execute Query. Defaults to DefaultClient.
+	Client *Client
+
+	// Query selects the papers to follow. Start is reset to 0 on
+	// every poll so each request starts from the top of the result
+	// set; Max is left as given and should be set high enough to
+	// cover however many papers might appear between polls.
+	Query *Query
+
+	// Interval is how often Start re-executes Query. Defaults to 15
+	// minutes if zero.
+	Interval time.Duration
+
+	store StateStore
+}
+
+// Function NewSubscription returns a Subscription over q, persisting
+// its progress to store. If client is nil, DefaultClient is used.
+func NewSubscription(client *Client, q *Query, store StateStore) *Subscription {
+	if client == nil {
+		client = DefaultClient
+	}
+	return &Subscription{
+		Client: client,
+		Query:  q,
+		store:  store,
+	}
+}
+
+// Method Poll executes the subscription's Query once and returns only
+// the papers published since the last successful Poll, oldest first.
+// The new high-water mark is persisted via the Subscription's
+// StateStore before Poll returns.
+func (s *Subscription) Poll(ctx context.Context) ([]Paper, error) {
+	state, err := s.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = DefaultClient
+	}
+
+	q := *s.Query
+	q.Start = 0
+	result, err := client.SearchContext(ctx, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(state.SeenIDs))
+	for _, id := range state.SeenIDs {
+		seen[id] = true
+	}
+
+	out := make([]Paper, 0)
+	newLast := state.LastPublished
+	// Seed with the prior SeenIDs so that, if no paper advances the
+	// high-water mark this poll, SeenIDs still reflects the full set
+	// of papers seen at LastPublished rather than just this poll's.
+	newSeen := append([]string(nil), state.SeenIDs...)
+	for _, paper := range result.Papers {
+		switch {
+		case paper.Published.Before(state.LastPublished):
+			continue
+		case paper.Published.Equal(state.LastPublished):
+			if seen[paper.ID()] {
+				continue
+			}
+		}
+		out = append(out, paper)
+
+		if paper.Published.After(newLast) {
+			newLast = paper.Published
+			newSeen = []string{paper.ID()}
+		} else if paper.Published.Equal(newLast) {
+			newSeen = append(newSeen, paper.ID())
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Published.Before(out[j].Published)
+	})
+
+	if len(out) > 0 {
+		if err := s.store.Save(State{LastPublished: newLast, SeenIDs: newSeen}); err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// Method Start runs Poll on Interval until ctx is done, delivering new
+// papers on the returned channel in publication order. The channel is
+// closed when ctx is done. Poll errors are dropped silently so that a
+// single transient failure (e.g. a network blip) doesn't end the
+// subscription; callers that need to observe errors should drive Poll
+// themselves instead.
+func (s *Subscription) Start(ctx context.Context) <-chan Paper {
+	interval := s.Interval
+	if interval == 0 {
+		interval = 15 * time.Minute
+	}
+
+	out := make(chan Paper)
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			papers, err := s.Poll(ctx)
+			if err == nil {
+				for _, paper := range papers {
+					select {
+					case out <- paper:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}